@@ -1,6 +1,11 @@
 package main;
+import "context"
 import "time"
+import "strings"
 import "database/sql"
+import "encoding/base64"
+import "encoding/json"
+import "fmt"
 import "github.com/google/uuid"
 
 
@@ -22,49 +27,227 @@ type User struct {
 	SubscriptionId *string `json:"subscription_id"`
 	EmailId string `json:"email_id"`
 }
-func UserByEmail(db *sql.DB, _email string) (*User, error) {
-	rows, err := db.Query("SELECT users.id AS id, users.created_date AS created_date, users.last_login AS last_login, users.name AS name, users.email AS email, users.user_type AS user_type, users.subscription_id AS subscription_id, LOWER(name) || '_' || LOWER(email) AS email_id FROM users WHERE users.email = $1;", _email)
+
+type DBTX interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+	PrepareContext(ctx context.Context, query string) (*sql.Stmt, error)
+}
+
+type Queries struct {
+	db DBTX
+}
+
+func New(db DBTX) *Queries {
+	return &Queries{db: db}
+}
+
+func (q *Queries) WithTx(tx *sql.Tx) *Queries {
+	return &Queries{db: tx}
+}
+
+// userByEmailTimeout is generated from a `@timeout(2s)` annotation on the
+// UserByEmail query in the schema DSL.
+const userByEmailTimeout = 2 * time.Second
+
+func (q *Queries) UserByEmail(ctx context.Context, _email string) (*User, error) {
+	ctx, cancel := context.WithTimeout(ctx, userByEmailTimeout)
+	defer cancel()
+
+	rows, err := q.db.QueryContext(ctx, "SELECT users.id AS id, users.created_date AS created_date, users.last_login AS last_login, users.name AS name, users.email AS email, users.user_type AS user_type, users.subscription_id AS subscription_id, LOWER(name) || '_' || LOWER(email) AS email_id FROM users WHERE LOWER(users.email) = LOWER($1);", _email)
 	if err != nil {
-		return nil, err		
+		return nil, err
 	}
 	defer rows.Close()
-	
-	return nil, nil
+
+	if !rows.Next() {
+		if err := rows.Err(); err != nil {
+			return nil, err
+		}
+		return nil, sql.ErrNoRows
+	}
+
+	var i User
+	var lastLogin sql.NullTime
+	var subscriptionId sql.NullString
+	if err := rows.Scan(
+		&i.Id,
+		&i.CreatedDate,
+		&lastLogin,
+		&i.Name,
+		&i.Email,
+		&i.UserType,
+		&subscriptionId,
+		&i.EmailId,
+	); err != nil {
+		return nil, err
+	}
+	if lastLogin.Valid {
+		i.LastLogin = &lastLogin.Time
+	}
+	if subscriptionId.Valid {
+		i.SubscriptionId = &subscriptionId.String
+	}
+
+	return &i, nil
 }
-func UsersByType(db *sql.DB, _typ UserType) ([]User, error) {
-	values := make([]User, 0)
-	rows, err := db.Query("SELECT users.id AS id, users.created_date AS created_date, users.last_login AS last_login, users.name AS name, users.email AS email, users.user_type AS user_type, users.subscription_id AS subscription_id, LOWER(name) || '_' || LOWER(email) AS email_id FROM users WHERE users.user_type = $1;", _typ)
+type UserCursor struct {
+	CreatedDate time.Time `json:"created_date"`
+	Id uuid.UUID `json:"id"`
+}
+
+func EncodeCursor(c *UserCursor) (string, error) {
+	if c == nil {
+		return "", nil
+	}
+	b, err := json.Marshal(c)
 	if err != nil {
-		return values, err		
+		return "", err
 	}
-	defer rows.Close()
-	
-	return values, nil
+	return base64.StdEncoding.EncodeToString(b), nil
 }
-func DeleteUserById(db *sql.DB, _id uuid.UUID) error {
-	rows, err := db.Query("DELETE FROM users WHERE users.id = $1;", _id)
+
+func DecodeCursor(s string) (*UserCursor, error) {
+	if s == "" {
+		return nil, nil
+	}
+	b, err := base64.StdEncoding.DecodeString(s)
 	if err != nil {
-		return err		
+		return nil, err
+	}
+	var c UserCursor
+	if err := json.Unmarshal(b, &c); err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
+
+func (q *Queries) UsersByType(ctx context.Context, _typ UserType, cursor *UserCursor, limit int32) ([]User, *UserCursor, error) {
+	return q.usersByType(ctx, _typ, cursor, limit, false)
+}
+
+func (q *Queries) UsersByTypeDesc(ctx context.Context, _typ UserType, cursor *UserCursor, limit int32) ([]User, *UserCursor, error) {
+	return q.usersByType(ctx, _typ, cursor, limit, true)
+}
+
+func (q *Queries) usersByType(ctx context.Context, _typ UserType, cursor *UserCursor, limit int32, desc bool) ([]User, *UserCursor, error) {
+	cmp, order := ">", "ORDER BY users.created_date, users.id"
+	if desc {
+		cmp, order = "<", "ORDER BY users.created_date DESC, users.id DESC"
+	}
+
+	query := "SELECT users.id AS id, users.created_date AS created_date, users.last_login AS last_login, users.name AS name, users.email AS email, users.user_type AS user_type, users.subscription_id AS subscription_id, LOWER(name) || '_' || LOWER(email) AS email_id FROM users WHERE users.user_type = $1"
+	args := []any{_typ}
+	if cursor != nil {
+		query += fmt.Sprintf(" AND (users.created_date, users.id) %s ($2, $3) %s LIMIT $4;", cmp, order)
+		args = append(args, cursor.CreatedDate, cursor.Id, limit)
+	} else {
+		query += fmt.Sprintf(" %s LIMIT $2;", order)
+		args = append(args, limit)
+	}
+
+	rows, err := q.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, nil, err
 	}
 	defer rows.Close()
-	return nil
+
+	values := make([]User, 0)
+	for rows.Next() {
+		var i User
+		var lastLogin sql.NullTime
+		var subscriptionId sql.NullString
+		if err := rows.Scan(
+			&i.Id,
+			&i.CreatedDate,
+			&lastLogin,
+			&i.Name,
+			&i.Email,
+			&i.UserType,
+			&subscriptionId,
+			&i.EmailId,
+		); err != nil {
+			return nil, nil, err
+		}
+		if lastLogin.Valid {
+			i.LastLogin = &lastLogin.Time
+		}
+		if subscriptionId.Valid {
+			i.SubscriptionId = &subscriptionId.String
+		}
+		values = append(values, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, nil, err
+	}
+
+	var next *UserCursor
+	if len(values) == int(limit) {
+		last := values[len(values)-1]
+		next = &UserCursor{CreatedDate: last.CreatedDate, Id: last.Id}
+	}
+
+	return values, next, nil
 }
-func CreateUser(db *sql.DB, __id uuid.UUID, __name string, __email string, __user_type UserType) (*User, error) {
-	rows, err := db.Query("WITH users AS (INSERT INTO users (id, name, email, user_type) VALUES ($1, $2, $3, $4) RETURNING *) AS users SELECT users.id AS id, users.created_date AS created_date, users.last_login AS last_login, users.name AS name, users.email AS email, users.user_type AS user_type, users.subscription_id AS subscription_id, LOWER(name) || '_' || LOWER(email) AS email_id FROM users;", __id, __name, __email, __user_type)
+func (q *Queries) DeleteUserById(ctx context.Context, _id uuid.UUID) error {
+	_, err := q.db.ExecContext(ctx, "DELETE FROM users WHERE users.id = $1;", _id)
+	return err
+}
+func (q *Queries) CreateUser(ctx context.Context, __id uuid.UUID, __name string, __email string, __user_type UserType) (*User, error) {
+	__email = strings.ToLower(__email)
+	rows, err := q.db.QueryContext(ctx, "WITH users AS (INSERT INTO users (id, name, email, user_type) VALUES ($1, $2, $3, $4) RETURNING *) SELECT users.id AS id, users.created_date AS created_date, users.last_login AS last_login, users.name AS name, users.email AS email, users.user_type AS user_type, users.subscription_id AS subscription_id, LOWER(name) || '_' || LOWER(email) AS email_id FROM users;", __id, __name, __email, __user_type)
 	if err != nil {
-		return nil, err		
+		return nil, err
 	}
 	defer rows.Close()
-	
-	return nil, nil
+
+	if !rows.Next() {
+		if err := rows.Err(); err != nil {
+			return nil, err
+		}
+		return nil, sql.ErrNoRows
+	}
+
+	var i User
+	var lastLogin sql.NullTime
+	var subscriptionId sql.NullString
+	if err := rows.Scan(
+		&i.Id,
+		&i.CreatedDate,
+		&lastLogin,
+		&i.Name,
+		&i.Email,
+		&i.UserType,
+		&subscriptionId,
+		&i.EmailId,
+	); err != nil {
+		return nil, err
+	}
+	if lastLogin.Valid {
+		i.LastLogin = &lastLogin.Time
+	}
+	if subscriptionId.Valid {
+		i.SubscriptionId = &subscriptionId.String
+	}
+
+	return &i, nil
 }
-func UpdateUserEmail(db *sql.DB, _id uuid.UUID, _email string) error {
-	rows, err := db.Query("WITH users AS (UPDATE users SET email = $1 WHERE id = $2 RETURNING *) SELECT users.id AS id, users.created_date AS created_date, users.last_login AS last_login, users.name AS name, users.email AS email, users.user_type AS user_type, users.subscription_id AS subscription_id, LOWER(name) || '_' || LOWER(email) AS email_id FROM users;", _id, _email)
+func (q *Queries) UpdateUserEmail(ctx context.Context, _id uuid.UUID, _email string) error {
+	_email = strings.ToLower(_email)
+	rows, err := q.db.QueryContext(ctx, "WITH users AS (UPDATE users SET email = $1 WHERE id = $2 RETURNING *) SELECT users.id AS id, users.created_date AS created_date, users.last_login AS last_login, users.name AS name, users.email AS email, users.user_type AS user_type, users.subscription_id AS subscription_id, LOWER(name) || '_' || LOWER(email) AS email_id FROM users;", _email, _id)
 	if err != nil {
-		return err		
+		return err
 	}
 	defer rows.Close()
-	return nil
+
+	if !rows.Next() {
+		if err := rows.Err(); err != nil {
+			return err
+		}
+		return sql.ErrNoRows
+	}
+	return rows.Err()
 }
 type Token struct {
 	Id uuid.UUID `json:"id"`
@@ -76,13 +259,23 @@ type UserWithToken struct {
 	UserId uuid.UUID `json:"user_id"`
 	TokenValue uuid.UUID `json:"token_value"`
 }
-func UserToken(db *sql.DB, _id uuid.UUID) ([]UserWithToken, error) {
-	values := make([]UserWithToken, 0)
-	rows, err := db.Query("SELECT users.id AS user_id, t.token_value AS token_value FROM users INNER JOIN tokens t ON users.id = t.user_id WHERE users.id = $1;", _id)
+func (q *Queries) UserToken(ctx context.Context, _id uuid.UUID) ([]UserWithToken, error) {
+	rows, err := q.db.QueryContext(ctx, "SELECT users.id AS user_id, t.token_value AS token_value FROM users INNER JOIN tokens t ON users.id = t.user_id WHERE users.id = $1;", _id)
 	if err != nil {
-		return values, err		
+		return nil, err
 	}
 	defer rows.Close()
-	
+
+	values := make([]UserWithToken, 0)
+	for rows.Next() {
+		var i UserWithToken
+		if err := rows.Scan(&i.UserId, &i.TokenValue); err != nil {
+			return nil, err
+		}
+		values = append(values, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
 	return values, nil
 }
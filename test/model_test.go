@@ -0,0 +1,298 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type fakeRows struct {
+	cols []string
+	data [][]driver.Value
+	pos  int
+}
+
+func (r *fakeRows) Columns() []string { return r.cols }
+func (r *fakeRows) Close() error      { return nil }
+func (r *fakeRows) Next(dest []driver.Value) error {
+	if r.pos >= len(r.data) {
+		return io.EOF
+	}
+	copy(dest, r.data[r.pos])
+	r.pos++
+	return nil
+}
+
+type fakeStmt struct {
+	rows     *fakeRows
+	lastArgs []driver.Value
+}
+
+func (s *fakeStmt) Close() error                                    { return nil }
+func (s *fakeStmt) NumInput() int                                   { return -1 }
+func (s *fakeStmt) Exec(args []driver.Value) (driver.Result, error) { s.lastArgs = args; return driver.ResultNoRows, nil }
+func (s *fakeStmt) Query(args []driver.Value) (driver.Rows, error)  { s.lastArgs = args; return s.rows, nil }
+
+type fakeConn struct {
+	stmt      *fakeStmt
+	lastQuery string
+}
+
+func (c *fakeConn) Prepare(query string) (driver.Stmt, error) {
+	c.lastQuery = query
+	return c.stmt, nil
+}
+func (c *fakeConn) Close() error { return nil }
+func (c *fakeConn) Begin() (driver.Tx, error) {
+	return nil, errors.New("fakeConn: transactions not supported")
+}
+
+type fakeDriver struct{ conn *fakeConn }
+
+func (d *fakeDriver) Open(name string) (driver.Conn, error) { return d.conn, nil }
+
+func openFakeDB(t *testing.T, rows *fakeRows) (*sql.DB, *fakeConn) {
+	t.Helper()
+	name := "repack-fake-" + t.Name()
+	conn := &fakeConn{stmt: &fakeStmt{rows: rows}}
+	sql.Register(name, &fakeDriver{conn: conn})
+	db, err := sql.Open(name, "")
+	if err != nil {
+		t.Fatalf("sql.Open: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+	return db, conn
+}
+
+func userRow(lastLogin, subscriptionId driver.Value) []driver.Value {
+	return []driver.Value{
+		"11111111-1111-1111-1111-111111111111",
+		time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		lastLogin,
+		"Ada",
+		"ada@example.com",
+		"Admin",
+		subscriptionId,
+		"ada_ada@example.com",
+	}
+}
+
+// TestUserByEmailScansAllColumnTypes asserts the generated scan code
+// correctly handles the UUID (id), enum (user_type), and timestamp
+// (created_date) columns when the nullable columns are present.
+func TestUserByEmailScansAllColumnTypes(t *testing.T) {
+	lastLogin := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	db, _ := openFakeDB(t, &fakeRows{
+		cols: []string{"id", "created_date", "last_login", "name", "email", "user_type", "subscription_id", "email_id"},
+		data: [][]driver.Value{userRow(lastLogin, "sub_123")},
+	})
+
+	user, err := New(db).UserByEmail(context.Background(), "ada@example.com")
+	if err != nil {
+		t.Fatalf("UserByEmail: %v", err)
+	}
+	if user.Id.String() != "11111111-1111-1111-1111-111111111111" {
+		t.Errorf("Id = %s, want 11111111-1111-1111-1111-111111111111", user.Id)
+	}
+	if !user.CreatedDate.Equal(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("CreatedDate = %v, want 2024-01-01", user.CreatedDate)
+	}
+	if user.UserType != UserTypeAdmin {
+		t.Errorf("UserType = %v, want %v", user.UserType, UserTypeAdmin)
+	}
+	if user.LastLogin == nil || !user.LastLogin.Equal(lastLogin) {
+		t.Errorf("LastLogin = %v, want %v", user.LastLogin, lastLogin)
+	}
+	if user.SubscriptionId == nil || *user.SubscriptionId != "sub_123" {
+		t.Errorf("SubscriptionId = %v, want sub_123", user.SubscriptionId)
+	}
+}
+
+// TestUserByEmailScansNullableColumns asserts NULL last_login and
+// subscription_id come back as nil pointers instead of zero values.
+func TestUserByEmailScansNullableColumns(t *testing.T) {
+	db, _ := openFakeDB(t, &fakeRows{
+		cols: []string{"id", "created_date", "last_login", "name", "email", "user_type", "subscription_id", "email_id"},
+		data: [][]driver.Value{userRow(nil, nil)},
+	})
+
+	user, err := New(db).UserByEmail(context.Background(), "ada@example.com")
+	if err != nil {
+		t.Fatalf("UserByEmail: %v", err)
+	}
+	if user.LastLogin != nil {
+		t.Errorf("LastLogin = %v, want nil", user.LastLogin)
+	}
+	if user.SubscriptionId != nil {
+		t.Errorf("SubscriptionId = %v, want nil", user.SubscriptionId)
+	}
+}
+
+// TestUserByEmailNoRows asserts an empty result set maps to sql.ErrNoRows
+// instead of a silent nil, nil.
+func TestUserByEmailNoRows(t *testing.T) {
+	db, _ := openFakeDB(t, &fakeRows{
+		cols: []string{"id", "created_date", "last_login", "name", "email", "user_type", "subscription_id", "email_id"},
+		data: nil,
+	})
+
+	user, err := New(db).UserByEmail(context.Background(), "nobody@example.com")
+	if !errors.Is(err, sql.ErrNoRows) {
+		t.Fatalf("err = %v, want sql.ErrNoRows", err)
+	}
+	if user != nil {
+		t.Errorf("user = %v, want nil", user)
+	}
+}
+
+// TestUsersByTypeScansEveryRow asserts the list variant scans each row in
+// the result set, including a mix of null and populated nullable columns.
+func TestUsersByTypeScansEveryRow(t *testing.T) {
+	db, _ := openFakeDB(t, &fakeRows{
+		cols: []string{"id", "created_date", "last_login", "name", "email", "user_type", "subscription_id", "email_id"},
+		data: [][]driver.Value{
+			userRow(nil, nil),
+			userRow(time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC), "sub_123"),
+		},
+	})
+
+	users, _, err := New(db).UsersByType(context.Background(), UserTypeAdmin, nil, 10)
+	if err != nil {
+		t.Fatalf("UsersByType: %v", err)
+	}
+	if len(users) != 2 {
+		t.Fatalf("len(users) = %d, want 2", len(users))
+	}
+	if users[0].LastLogin != nil || users[0].SubscriptionId != nil {
+		t.Errorf("users[0] nullable columns should be nil, got %+v", users[0])
+	}
+	if users[1].LastLogin == nil || users[1].SubscriptionId == nil {
+		t.Errorf("users[1] nullable columns should be populated, got %+v", users[1])
+	}
+}
+
+// TestUsersByTypeNilCursorFetchesFirstPage asserts a nil cursor queries
+// without a keyset predicate.
+func TestUsersByTypeNilCursorFetchesFirstPage(t *testing.T) {
+	db, conn := openFakeDB(t, &fakeRows{
+		cols: []string{"id", "created_date", "last_login", "name", "email", "user_type", "subscription_id", "email_id"},
+		data: [][]driver.Value{userRow(nil, nil)},
+	})
+
+	if _, _, err := New(db).UsersByType(context.Background(), UserTypeAdmin, nil, 10); err != nil {
+		t.Fatalf("UsersByType: %v", err)
+	}
+	if strings.Contains(conn.lastQuery, "(users.created_date, users.id) >") {
+		t.Errorf("query with nil cursor should not include a keyset predicate, got %q", conn.lastQuery)
+	}
+}
+
+// TestUsersByTypeEmptyResultHasNilNextCursor asserts an empty page never
+// returns a non-nil cursor for the caller to loop on forever.
+func TestUsersByTypeEmptyResultHasNilNextCursor(t *testing.T) {
+	db, _ := openFakeDB(t, &fakeRows{
+		cols: []string{"id", "created_date", "last_login", "name", "email", "user_type", "subscription_id", "email_id"},
+		data: nil,
+	})
+
+	users, next, err := New(db).UsersByType(context.Background(), UserTypeAdmin, nil, 10)
+	if err != nil {
+		t.Fatalf("UsersByType: %v", err)
+	}
+	if len(users) != 0 {
+		t.Fatalf("len(users) = %d, want 0", len(users))
+	}
+	if next != nil {
+		t.Errorf("next = %v, want nil", next)
+	}
+}
+
+// TestUsersByTypePartialPageHasNilNextCursor asserts a page shorter than
+// the requested limit is treated as the last page, sparing the caller an
+// extra round-trip that would come back empty.
+func TestUsersByTypePartialPageHasNilNextCursor(t *testing.T) {
+	db, _ := openFakeDB(t, &fakeRows{
+		cols: []string{"id", "created_date", "last_login", "name", "email", "user_type", "subscription_id", "email_id"},
+		data: [][]driver.Value{userRow(nil, nil)},
+	})
+
+	users, next, err := New(db).UsersByType(context.Background(), UserTypeAdmin, nil, 10)
+	if err != nil {
+		t.Fatalf("UsersByType: %v", err)
+	}
+	if len(users) != 1 {
+		t.Fatalf("len(users) = %d, want 1", len(users))
+	}
+	if next != nil {
+		t.Errorf("next = %v, want nil for a partial page", next)
+	}
+}
+
+// TestUsersByTypeFullPageHasNextCursor asserts a full page still returns a
+// cursor, since a partial page is the only reliable end-of-results signal.
+func TestUsersByTypeFullPageHasNextCursor(t *testing.T) {
+	db, _ := openFakeDB(t, &fakeRows{
+		cols: []string{"id", "created_date", "last_login", "name", "email", "user_type", "subscription_id", "email_id"},
+		data: [][]driver.Value{userRow(nil, nil)},
+	})
+
+	_, next, err := New(db).UsersByType(context.Background(), UserTypeAdmin, nil, 1)
+	if err != nil {
+		t.Fatalf("UsersByType: %v", err)
+	}
+	if next == nil {
+		t.Errorf("next = nil, want non-nil for a full page")
+	}
+}
+
+// TestUsersByTypeDescUsesDescendingKeyset asserts the descending variant
+// flips both the ORDER BY direction and the keyset comparison operator.
+func TestUsersByTypeDescUsesDescendingKeyset(t *testing.T) {
+	db, conn := openFakeDB(t, &fakeRows{
+		cols: []string{"id", "created_date", "last_login", "name", "email", "user_type", "subscription_id", "email_id"},
+		data: [][]driver.Value{userRow(nil, nil)},
+	})
+
+	cursor := &UserCursor{CreatedDate: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), Id: uuid.New()}
+	if _, _, err := New(db).UsersByTypeDesc(context.Background(), UserTypeAdmin, cursor, 10); err != nil {
+		t.Fatalf("UsersByTypeDesc: %v", err)
+	}
+	if !strings.Contains(conn.lastQuery, "ORDER BY users.created_date DESC, users.id DESC") {
+		t.Errorf("query should order descending, got %q", conn.lastQuery)
+	}
+	if !strings.Contains(conn.lastQuery, "(users.created_date, users.id) < ($2, $3)") {
+		t.Errorf("query should use < for the descending keyset predicate, got %q", conn.lastQuery)
+	}
+}
+
+// TestUpdateUserEmailBindsEmailThenId asserts the $1/$2 placeholders in
+// "SET email = $1 WHERE id = $2" are bound to (_email, _id), not
+// (_id, _email) — swapping them writes the UUID into the email column.
+func TestUpdateUserEmailBindsEmailThenId(t *testing.T) {
+	db, conn := openFakeDB(t, &fakeRows{
+		cols: []string{"id", "created_date", "last_login", "name", "email", "user_type", "subscription_id", "email_id"},
+		data: [][]driver.Value{userRow(nil, nil)},
+	})
+
+	id := uuid.New()
+	if err := New(db).UpdateUserEmail(context.Background(), id, "New@Example.com"); err != nil {
+		t.Fatalf("UpdateUserEmail: %v", err)
+	}
+	args := conn.stmt.lastArgs
+	if len(args) != 2 {
+		t.Fatalf("len(args) = %d, want 2", len(args))
+	}
+	if args[0] != "new@example.com" {
+		t.Errorf("args[0] (bound to $1/email) = %v, want new@example.com", args[0])
+	}
+	if args[1] != id.String() && args[1] != id {
+		t.Errorf("args[1] (bound to $2/id) = %v, want %v", args[1], id)
+	}
+}
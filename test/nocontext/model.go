@@ -0,0 +1,268 @@
+package main;
+import "time"
+import "strings"
+import "database/sql"
+import "encoding/base64"
+import "encoding/json"
+import "github.com/google/uuid"
+
+
+type UserType string
+
+const (
+	UserTypeAdmin UserType = "Admin"
+	UserTypeUser UserType = "User"
+	UserTypeGuest UserType = "Guest"
+)
+
+type User struct {
+	Id uuid.UUID `json:"id"`
+	CreatedDate time.Time `json:"created_date"`
+	LastLogin *time.Time `json:"last_login"`
+	Name string `json:"name"`
+	Email string `json:"email"`
+	UserType UserType `json:"user_type"`
+	SubscriptionId *string `json:"subscription_id"`
+	EmailId string `json:"email_id"`
+}
+
+// DBTX and every generated method below omit context.Context: this is the
+// output of `--context=false`, kept for callers mid-migration to the
+// context-aware API who aren't ready to thread ctx through yet.
+type DBTX interface {
+	Exec(query string, args ...any) (sql.Result, error)
+	Query(query string, args ...any) (*sql.Rows, error)
+	QueryRow(query string, args ...any) *sql.Row
+	Prepare(query string) (*sql.Stmt, error)
+}
+
+type Queries struct {
+	db DBTX
+}
+
+func New(db DBTX) *Queries {
+	return &Queries{db: db}
+}
+
+func (q *Queries) WithTx(tx *sql.Tx) *Queries {
+	return &Queries{db: tx}
+}
+
+func (q *Queries) UserByEmail(_email string) (*User, error) {
+	rows, err := q.db.Query("SELECT users.id AS id, users.created_date AS created_date, users.last_login AS last_login, users.name AS name, users.email AS email, users.user_type AS user_type, users.subscription_id AS subscription_id, LOWER(name) || '_' || LOWER(email) AS email_id FROM users WHERE LOWER(users.email) = LOWER($1);", _email)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	if !rows.Next() {
+		if err := rows.Err(); err != nil {
+			return nil, err
+		}
+		return nil, sql.ErrNoRows
+	}
+
+	var i User
+	var lastLogin sql.NullTime
+	var subscriptionId sql.NullString
+	if err := rows.Scan(
+		&i.Id,
+		&i.CreatedDate,
+		&lastLogin,
+		&i.Name,
+		&i.Email,
+		&i.UserType,
+		&subscriptionId,
+		&i.EmailId,
+	); err != nil {
+		return nil, err
+	}
+	if lastLogin.Valid {
+		i.LastLogin = &lastLogin.Time
+	}
+	if subscriptionId.Valid {
+		i.SubscriptionId = &subscriptionId.String
+	}
+
+	return &i, nil
+}
+
+type UserCursor struct {
+	CreatedDate time.Time `json:"created_date"`
+	Id uuid.UUID `json:"id"`
+}
+
+func EncodeCursor(c *UserCursor) (string, error) {
+	if c == nil {
+		return "", nil
+	}
+	b, err := json.Marshal(c)
+	if err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(b), nil
+}
+
+func DecodeCursor(s string) (*UserCursor, error) {
+	if s == "" {
+		return nil, nil
+	}
+	b, err := base64.StdEncoding.DecodeString(s)
+	if err != nil {
+		return nil, err
+	}
+	var c UserCursor
+	if err := json.Unmarshal(b, &c); err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
+
+func (q *Queries) UsersByType(_typ UserType, cursor *UserCursor, limit int32) ([]User, *UserCursor, error) {
+	query := "SELECT users.id AS id, users.created_date AS created_date, users.last_login AS last_login, users.name AS name, users.email AS email, users.user_type AS user_type, users.subscription_id AS subscription_id, LOWER(name) || '_' || LOWER(email) AS email_id FROM users WHERE users.user_type = $1"
+	args := []any{_typ}
+	if cursor != nil {
+		query += " AND (users.created_date, users.id) > ($2, $3) ORDER BY users.created_date, users.id LIMIT $4;"
+		args = append(args, cursor.CreatedDate, cursor.Id, limit)
+	} else {
+		query += " ORDER BY users.created_date, users.id LIMIT $2;"
+		args = append(args, limit)
+	}
+
+	rows, err := q.db.Query(query, args...)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer rows.Close()
+
+	values := make([]User, 0)
+	for rows.Next() {
+		var i User
+		var lastLogin sql.NullTime
+		var subscriptionId sql.NullString
+		if err := rows.Scan(
+			&i.Id,
+			&i.CreatedDate,
+			&lastLogin,
+			&i.Name,
+			&i.Email,
+			&i.UserType,
+			&subscriptionId,
+			&i.EmailId,
+		); err != nil {
+			return nil, nil, err
+		}
+		if lastLogin.Valid {
+			i.LastLogin = &lastLogin.Time
+		}
+		if subscriptionId.Valid {
+			i.SubscriptionId = &subscriptionId.String
+		}
+		values = append(values, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, nil, err
+	}
+
+	var next *UserCursor
+	if len(values) == int(limit) {
+		last := values[len(values)-1]
+		next = &UserCursor{CreatedDate: last.CreatedDate, Id: last.Id}
+	}
+
+	return values, next, nil
+}
+
+func (q *Queries) DeleteUserById(_id uuid.UUID) error {
+	_, err := q.db.Exec("DELETE FROM users WHERE users.id = $1;", _id)
+	return err
+}
+
+func (q *Queries) CreateUser(__id uuid.UUID, __name string, __email string, __user_type UserType) (*User, error) {
+	__email = strings.ToLower(__email)
+	rows, err := q.db.Query("WITH users AS (INSERT INTO users (id, name, email, user_type) VALUES ($1, $2, $3, $4) RETURNING *) SELECT users.id AS id, users.created_date AS created_date, users.last_login AS last_login, users.name AS name, users.email AS email, users.user_type AS user_type, users.subscription_id AS subscription_id, LOWER(name) || '_' || LOWER(email) AS email_id FROM users;", __id, __name, __email, __user_type)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	if !rows.Next() {
+		if err := rows.Err(); err != nil {
+			return nil, err
+		}
+		return nil, sql.ErrNoRows
+	}
+
+	var i User
+	var lastLogin sql.NullTime
+	var subscriptionId sql.NullString
+	if err := rows.Scan(
+		&i.Id,
+		&i.CreatedDate,
+		&lastLogin,
+		&i.Name,
+		&i.Email,
+		&i.UserType,
+		&subscriptionId,
+		&i.EmailId,
+	); err != nil {
+		return nil, err
+	}
+	if lastLogin.Valid {
+		i.LastLogin = &lastLogin.Time
+	}
+	if subscriptionId.Valid {
+		i.SubscriptionId = &subscriptionId.String
+	}
+
+	return &i, nil
+}
+
+func (q *Queries) UpdateUserEmail(_id uuid.UUID, _email string) error {
+	_email = strings.ToLower(_email)
+	rows, err := q.db.Query("WITH users AS (UPDATE users SET email = $1 WHERE id = $2 RETURNING *) SELECT users.id AS id, users.created_date AS created_date, users.last_login AS last_login, users.name AS name, users.email AS email, users.user_type AS user_type, users.subscription_id AS subscription_id, LOWER(name) || '_' || LOWER(email) AS email_id FROM users;", _email, _id)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	if !rows.Next() {
+		if err := rows.Err(); err != nil {
+			return err
+		}
+		return sql.ErrNoRows
+	}
+	return rows.Err()
+}
+
+type Token struct {
+	Id uuid.UUID `json:"id"`
+	CreatedDate time.Time `json:"created_date"`
+	UserId uuid.UUID `json:"user_id"`
+	TokenValue uuid.UUID `json:"token_value"`
+}
+type UserWithToken struct {
+	UserId uuid.UUID `json:"user_id"`
+	TokenValue uuid.UUID `json:"token_value"`
+}
+
+func (q *Queries) UserToken(_id uuid.UUID) ([]UserWithToken, error) {
+	rows, err := q.db.Query("SELECT users.id AS user_id, t.token_value AS token_value FROM users INNER JOIN tokens t ON users.id = t.user_id WHERE users.id = $1;", _id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	values := make([]UserWithToken, 0)
+	for rows.Next() {
+		var i UserWithToken
+		if err := rows.Scan(&i.UserId, &i.TokenValue); err != nil {
+			return nil, err
+		}
+		values = append(values, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return values, nil
+}
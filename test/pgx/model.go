@@ -0,0 +1,170 @@
+package main;
+import "context"
+import "strings"
+import "time"
+import "encoding/base64"
+import "encoding/json"
+import "github.com/google/uuid"
+import "github.com/jackc/pgx/v5"
+import "github.com/jackc/pgx/v5/pgconn"
+import "github.com/jackc/pgx/v5/pgtype"
+
+type UserType string
+
+const (
+	UserTypeAdmin UserType = "Admin"
+	UserTypeUser UserType = "User"
+	UserTypeGuest UserType = "Guest"
+)
+
+type User struct {
+	Id uuid.UUID `json:"id"`
+	CreatedDate pgtype.Timestamptz `json:"created_date"`
+	LastLogin pgtype.Timestamptz `json:"last_login"`
+	Name string `json:"name"`
+	Email string `json:"email"`
+	UserType UserType `json:"user_type"`
+	SubscriptionId pgtype.Text `json:"subscription_id"`
+	EmailId string `json:"email_id"`
+}
+
+type DBTX interface {
+	Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error)
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+}
+
+type Queries struct {
+	db DBTX
+}
+
+func New(db DBTX) *Queries {
+	return &Queries{db: db}
+}
+
+func (q *Queries) WithTx(tx pgx.Tx) *Queries {
+	return &Queries{db: tx}
+}
+
+func (q *Queries) UserByEmail(ctx context.Context, _email string) (*User, error) {
+	rows, err := q.db.Query(ctx, "SELECT users.id AS id, users.created_date AS created_date, users.last_login AS last_login, users.name AS name, users.email AS email, users.user_type AS user_type, users.subscription_id AS subscription_id, LOWER(name) || '_' || LOWER(email) AS email_id FROM users WHERE LOWER(users.email) = LOWER($1);", _email)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	user, err := pgx.CollectExactlyOneRow(rows, pgx.RowToStructByName[User])
+	if err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+type UserCursor struct {
+	CreatedDate time.Time `json:"created_date"`
+	Id uuid.UUID `json:"id"`
+}
+
+func EncodeCursor(c *UserCursor) (string, error) {
+	if c == nil {
+		return "", nil
+	}
+	b, err := json.Marshal(c)
+	if err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(b), nil
+}
+
+func DecodeCursor(s string) (*UserCursor, error) {
+	if s == "" {
+		return nil, nil
+	}
+	b, err := base64.StdEncoding.DecodeString(s)
+	if err != nil {
+		return nil, err
+	}
+	var c UserCursor
+	if err := json.Unmarshal(b, &c); err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
+
+func (q *Queries) UsersByType(ctx context.Context, _typ UserType, cursor *UserCursor, limit int32) ([]User, *UserCursor, error) {
+	query := "SELECT users.id AS id, users.created_date AS created_date, users.last_login AS last_login, users.name AS name, users.email AS email, users.user_type AS user_type, users.subscription_id AS subscription_id, LOWER(name) || '_' || LOWER(email) AS email_id FROM users WHERE users.user_type = $1"
+	args := []any{_typ}
+	if cursor != nil {
+		query += " AND (users.created_date, users.id) > ($2, $3) ORDER BY users.created_date, users.id LIMIT $4;"
+		args = append(args, cursor.CreatedDate, cursor.Id, limit)
+	} else {
+		query += " ORDER BY users.created_date, users.id LIMIT $2;"
+		args = append(args, limit)
+	}
+
+	rows, err := q.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer rows.Close()
+
+	values, err := pgx.CollectRows(rows, pgx.RowToStructByName[User])
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var next *UserCursor
+	if len(values) == int(limit) {
+		last := values[len(values)-1]
+		next = &UserCursor{CreatedDate: last.CreatedDate.Time, Id: last.Id}
+	}
+
+	return values, next, nil
+}
+
+func (q *Queries) DeleteUserById(ctx context.Context, _id uuid.UUID) error {
+	_, err := q.db.Exec(ctx, "DELETE FROM users WHERE users.id = $1;", _id)
+	return err
+}
+
+func (q *Queries) CreateUser(ctx context.Context, __id uuid.UUID, __name string, __email string, __user_type UserType) (*User, error) {
+	__email = strings.ToLower(__email)
+	rows, err := q.db.Query(ctx, "WITH users AS (INSERT INTO users (id, name, email, user_type) VALUES ($1, $2, $3, $4) RETURNING *) SELECT users.id AS id, users.created_date AS created_date, users.last_login AS last_login, users.name AS name, users.email AS email, users.user_type AS user_type, users.subscription_id AS subscription_id, LOWER(name) || '_' || LOWER(email) AS email_id FROM users;", __id, __name, __email, __user_type)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	user, err := pgx.CollectExactlyOneRow(rows, pgx.RowToStructByName[User])
+	if err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+func (q *Queries) UpdateUserEmail(ctx context.Context, _id uuid.UUID, _email string) error {
+	_email = strings.ToLower(_email)
+	_, err := q.db.Exec(ctx, "WITH users AS (UPDATE users SET email = $1 WHERE id = $2 RETURNING *) SELECT users.id AS id, users.created_date AS created_date, users.last_login AS last_login, users.name AS name, users.email AS email, users.user_type AS user_type, users.subscription_id AS subscription_id, LOWER(name) || '_' || LOWER(email) AS email_id FROM users;", _email, _id)
+	return err
+}
+
+type Token struct {
+	Id uuid.UUID `json:"id"`
+	CreatedDate pgtype.Timestamptz `json:"created_date"`
+	UserId uuid.UUID `json:"user_id"`
+	TokenValue uuid.UUID `json:"token_value"`
+}
+type UserWithToken struct {
+	UserId uuid.UUID `json:"user_id"`
+	TokenValue uuid.UUID `json:"token_value"`
+}
+
+func (q *Queries) UserToken(ctx context.Context, _id uuid.UUID) ([]UserWithToken, error) {
+	rows, err := q.db.Query(ctx, "SELECT users.id AS user_id, t.token_value AS token_value FROM users INNER JOIN tokens t ON users.id = t.user_id WHERE users.id = $1;", _id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return pgx.CollectRows(rows, pgx.RowToStructByName[UserWithToken])
+}
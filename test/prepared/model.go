@@ -0,0 +1,318 @@
+package main;
+import "context"
+import "time"
+import "strings"
+import "database/sql"
+import "encoding/base64"
+import "encoding/json"
+import "github.com/google/uuid"
+
+
+type UserType string
+
+const (
+	UserTypeAdmin UserType = "Admin"
+	UserTypeUser UserType = "User"
+	UserTypeGuest UserType = "Guest"
+)
+
+type User struct {
+	Id uuid.UUID `json:"id"`
+	CreatedDate time.Time `json:"created_date"`
+	LastLogin *time.Time `json:"last_login"`
+	Name string `json:"name"`
+	Email string `json:"email"`
+	UserType UserType `json:"user_type"`
+	SubscriptionId *string `json:"subscription_id"`
+	EmailId string `json:"email_id"`
+}
+
+type DBTX interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+	PrepareContext(ctx context.Context, query string) (*sql.Stmt, error)
+}
+
+type Queries struct {
+	db DBTX
+
+	userByEmailStmt *sql.Stmt
+	usersByTypeFirstPageStmt *sql.Stmt
+	usersByTypeNextPageStmt *sql.Stmt
+	deleteUserByIdStmt *sql.Stmt
+	createUserStmt *sql.Stmt
+	updateUserEmailStmt *sql.Stmt
+	userTokenStmt *sql.Stmt
+}
+
+func Prepare(ctx context.Context, db DBTX) (*Queries, error) {
+	q := &Queries{db: db}
+	var err error
+
+	if q.userByEmailStmt, err = db.PrepareContext(ctx, "SELECT users.id AS id, users.created_date AS created_date, users.last_login AS last_login, users.name AS name, users.email AS email, users.user_type AS user_type, users.subscription_id AS subscription_id, LOWER(name) || '_' || LOWER(email) AS email_id FROM users WHERE LOWER(users.email) = LOWER($1);"); err != nil {
+		return nil, err
+	}
+	if q.usersByTypeFirstPageStmt, err = db.PrepareContext(ctx, "SELECT users.id AS id, users.created_date AS created_date, users.last_login AS last_login, users.name AS name, users.email AS email, users.user_type AS user_type, users.subscription_id AS subscription_id, LOWER(name) || '_' || LOWER(email) AS email_id FROM users WHERE users.user_type = $1 ORDER BY users.created_date, users.id LIMIT $2;"); err != nil {
+		return nil, err
+	}
+	if q.usersByTypeNextPageStmt, err = db.PrepareContext(ctx, "SELECT users.id AS id, users.created_date AS created_date, users.last_login AS last_login, users.name AS name, users.email AS email, users.user_type AS user_type, users.subscription_id AS subscription_id, LOWER(name) || '_' || LOWER(email) AS email_id FROM users WHERE users.user_type = $1 AND (users.created_date, users.id) > ($2, $3) ORDER BY users.created_date, users.id LIMIT $4;"); err != nil {
+		return nil, err
+	}
+	if q.deleteUserByIdStmt, err = db.PrepareContext(ctx, "DELETE FROM users WHERE users.id = $1;"); err != nil {
+		return nil, err
+	}
+	if q.createUserStmt, err = db.PrepareContext(ctx, "WITH users AS (INSERT INTO users (id, name, email, user_type) VALUES ($1, $2, $3, $4) RETURNING *) SELECT users.id AS id, users.created_date AS created_date, users.last_login AS last_login, users.name AS name, users.email AS email, users.user_type AS user_type, users.subscription_id AS subscription_id, LOWER(name) || '_' || LOWER(email) AS email_id FROM users;"); err != nil {
+		return nil, err
+	}
+	if q.updateUserEmailStmt, err = db.PrepareContext(ctx, "WITH users AS (UPDATE users SET email = $1 WHERE id = $2 RETURNING *) SELECT users.id AS id, users.created_date AS created_date, users.last_login AS last_login, users.name AS name, users.email AS email, users.user_type AS user_type, users.subscription_id AS subscription_id, LOWER(name) || '_' || LOWER(email) AS email_id FROM users;"); err != nil {
+		return nil, err
+	}
+	if q.userTokenStmt, err = db.PrepareContext(ctx, "SELECT users.id AS user_id, t.token_value AS token_value FROM users INNER JOIN tokens t ON users.id = t.user_id WHERE users.id = $1;"); err != nil {
+		return nil, err
+	}
+
+	return q, nil
+}
+
+func (q *Queries) Close() error {
+	for _, stmt := range []*sql.Stmt{
+		q.userByEmailStmt,
+		q.usersByTypeFirstPageStmt,
+		q.usersByTypeNextPageStmt,
+		q.deleteUserByIdStmt,
+		q.createUserStmt,
+		q.updateUserEmailStmt,
+		q.userTokenStmt,
+	} {
+		if stmt != nil {
+			if err := stmt.Close(); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (q *Queries) WithTx(ctx context.Context, tx *sql.Tx) *Queries {
+	return &Queries{
+		db: tx,
+
+		userByEmailStmt: tx.StmtContext(ctx, q.userByEmailStmt),
+		usersByTypeFirstPageStmt: tx.StmtContext(ctx, q.usersByTypeFirstPageStmt),
+		usersByTypeNextPageStmt: tx.StmtContext(ctx, q.usersByTypeNextPageStmt),
+		deleteUserByIdStmt: tx.StmtContext(ctx, q.deleteUserByIdStmt),
+		createUserStmt: tx.StmtContext(ctx, q.createUserStmt),
+		updateUserEmailStmt: tx.StmtContext(ctx, q.updateUserEmailStmt),
+		userTokenStmt: tx.StmtContext(ctx, q.userTokenStmt),
+	}
+}
+
+func (q *Queries) UserByEmail(ctx context.Context, _email string) (*User, error) {
+	rows, err := q.userByEmailStmt.QueryContext(ctx, _email)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	if !rows.Next() {
+		if err := rows.Err(); err != nil {
+			return nil, err
+		}
+		return nil, sql.ErrNoRows
+	}
+
+	var i User
+	var lastLogin sql.NullTime
+	var subscriptionId sql.NullString
+	if err := rows.Scan(
+		&i.Id,
+		&i.CreatedDate,
+		&lastLogin,
+		&i.Name,
+		&i.Email,
+		&i.UserType,
+		&subscriptionId,
+		&i.EmailId,
+	); err != nil {
+		return nil, err
+	}
+	if lastLogin.Valid {
+		i.LastLogin = &lastLogin.Time
+	}
+	if subscriptionId.Valid {
+		i.SubscriptionId = &subscriptionId.String
+	}
+
+	return &i, nil
+}
+type UserCursor struct {
+	CreatedDate time.Time `json:"created_date"`
+	Id uuid.UUID `json:"id"`
+}
+
+func EncodeCursor(c *UserCursor) (string, error) {
+	if c == nil {
+		return "", nil
+	}
+	b, err := json.Marshal(c)
+	if err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(b), nil
+}
+
+func DecodeCursor(s string) (*UserCursor, error) {
+	if s == "" {
+		return nil, nil
+	}
+	b, err := base64.StdEncoding.DecodeString(s)
+	if err != nil {
+		return nil, err
+	}
+	var c UserCursor
+	if err := json.Unmarshal(b, &c); err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
+
+func (q *Queries) UsersByType(ctx context.Context, _typ UserType, cursor *UserCursor, limit int32) ([]User, *UserCursor, error) {
+	var rows *sql.Rows
+	var err error
+	if cursor != nil {
+		rows, err = q.usersByTypeNextPageStmt.QueryContext(ctx, _typ, cursor.CreatedDate, cursor.Id, limit)
+	} else {
+		rows, err = q.usersByTypeFirstPageStmt.QueryContext(ctx, _typ, limit)
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	defer rows.Close()
+
+	values := make([]User, 0)
+	for rows.Next() {
+		var i User
+		var lastLogin sql.NullTime
+		var subscriptionId sql.NullString
+		if err := rows.Scan(
+			&i.Id,
+			&i.CreatedDate,
+			&lastLogin,
+			&i.Name,
+			&i.Email,
+			&i.UserType,
+			&subscriptionId,
+			&i.EmailId,
+		); err != nil {
+			return nil, nil, err
+		}
+		if lastLogin.Valid {
+			i.LastLogin = &lastLogin.Time
+		}
+		if subscriptionId.Valid {
+			i.SubscriptionId = &subscriptionId.String
+		}
+		values = append(values, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, nil, err
+	}
+
+	var next *UserCursor
+	if len(values) == int(limit) {
+		last := values[len(values)-1]
+		next = &UserCursor{CreatedDate: last.CreatedDate, Id: last.Id}
+	}
+
+	return values, next, nil
+}
+func (q *Queries) DeleteUserById(ctx context.Context, _id uuid.UUID) error {
+	_, err := q.deleteUserByIdStmt.ExecContext(ctx, _id)
+	return err
+}
+func (q *Queries) CreateUser(ctx context.Context, __id uuid.UUID, __name string, __email string, __user_type UserType) (*User, error) {
+	__email = strings.ToLower(__email)
+	rows, err := q.createUserStmt.QueryContext(ctx, __id, __name, __email, __user_type)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	if !rows.Next() {
+		if err := rows.Err(); err != nil {
+			return nil, err
+		}
+		return nil, sql.ErrNoRows
+	}
+
+	var i User
+	var lastLogin sql.NullTime
+	var subscriptionId sql.NullString
+	if err := rows.Scan(
+		&i.Id,
+		&i.CreatedDate,
+		&lastLogin,
+		&i.Name,
+		&i.Email,
+		&i.UserType,
+		&subscriptionId,
+		&i.EmailId,
+	); err != nil {
+		return nil, err
+	}
+	if lastLogin.Valid {
+		i.LastLogin = &lastLogin.Time
+	}
+	if subscriptionId.Valid {
+		i.SubscriptionId = &subscriptionId.String
+	}
+
+	return &i, nil
+}
+func (q *Queries) UpdateUserEmail(ctx context.Context, _id uuid.UUID, _email string) error {
+	_email = strings.ToLower(_email)
+	rows, err := q.updateUserEmailStmt.QueryContext(ctx, _email, _id)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	if !rows.Next() {
+		if err := rows.Err(); err != nil {
+			return err
+		}
+		return sql.ErrNoRows
+	}
+	return rows.Err()
+}
+type Token struct {
+	Id uuid.UUID `json:"id"`
+	CreatedDate time.Time `json:"created_date"`
+	UserId uuid.UUID `json:"user_id"`
+	TokenValue uuid.UUID `json:"token_value"`
+}
+type UserWithToken struct {
+	UserId uuid.UUID `json:"user_id"`
+	TokenValue uuid.UUID `json:"token_value"`
+}
+func (q *Queries) UserToken(ctx context.Context, _id uuid.UUID) ([]UserWithToken, error) {
+	rows, err := q.userTokenStmt.QueryContext(ctx, _id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	values := make([]UserWithToken, 0)
+	for rows.Next() {
+		var i UserWithToken
+		if err := rows.Scan(&i.UserId, &i.TokenValue); err != nil {
+			return nil, err
+		}
+		values = append(values, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return values, nil
+}